@@ -0,0 +1,255 @@
+package reporter
+
+import (
+	"context"
+	"errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// AggregateReporter produces a single combined report for a root object and
+// everything transitively owned by it (via metadata.ownerReferences), e.g.
+// given a Deployment it can emit the Deployment, its ReplicaSets and their
+// Pods together. This is the common "dump everything related to X" use
+// case that otherwise requires instantiating one YamlReporter per resource
+// type and stitching the results together by hand.
+type AggregateReporter struct {
+	dynamicCli *dynamic.Interface
+	gvrs       []schema.GroupVersionResource
+	root       *ownerRootRef
+	maxDepth   int
+	format     Format
+	expression *string
+	redactor   Redactor
+}
+
+type ownerRootRef struct {
+	gvr       schema.GroupVersionResource
+	name      string
+	namespace string
+}
+
+// AggregateOption configures an AggregateReporter.
+type AggregateOption interface {
+	applyAggregate(*AggregateReporter)
+}
+
+type aggDynCliOption struct {
+	DynamicCli dynamic.Interface
+}
+
+func (d aggDynCliOption) applyAggregate(opts *AggregateReporter) {
+	opts.dynamicCli = &d.DynamicCli
+}
+
+// WithAggregateDynamicClient supplies the dynamic client used to list every
+// GVR passed to WithGVRs.
+func WithAggregateDynamicClient(dynamicCli dynamic.Interface) AggregateOption {
+	return aggDynCliOption{DynamicCli: dynamicCli}
+}
+
+type gvrsOption struct {
+	gvrs []schema.GroupVersionResource
+}
+
+func (g gvrsOption) applyAggregate(opts *AggregateReporter) {
+	opts.gvrs = g.gvrs
+}
+
+// WithGVRs sets the search domain: the GroupVersionResources that are
+// listed and searched for owner-reference matches when walking down from
+// the root set via WithRoot. The root itself is fetched on its own, so its
+// GVR need not be listed here.
+func WithGVRs(gvrs ...schema.GroupVersionResource) AggregateOption {
+	return gvrsOption{gvrs: gvrs}
+}
+
+type rootOption struct {
+	root ownerRootRef
+}
+
+func (r rootOption) applyAggregate(opts *AggregateReporter) {
+	opts.root = &r.root
+}
+
+// WithRoot sets the object the walk starts from.
+func WithRoot(gvr schema.GroupVersionResource, name, namespace string) AggregateOption {
+	return rootOption{root: ownerRootRef{gvr: gvr, name: name, namespace: namespace}}
+}
+
+type depthOption struct {
+	depth int
+}
+
+func (d depthOption) applyAggregate(opts *AggregateReporter) {
+	opts.maxDepth = d.depth
+}
+
+// WithDepth limits how many owner-reference hops are walked from the root.
+// A depth of 1 returns the root's direct owned objects only, 2 also
+// includes objects owned by those, and so on. When not supplied the walk
+// continues until no further owned objects are found.
+func WithDepth(depth int) AggregateOption {
+	return depthOption{depth: depth}
+}
+
+type aggFormatOption struct {
+	format Format
+}
+
+func (f aggFormatOption) applyAggregate(opts *AggregateReporter) {
+	opts.format = f.format
+}
+
+// WithAggregateFormat selects the output format, same semantics as WithFormat.
+func WithAggregateFormat(format Format) AggregateOption {
+	return aggFormatOption{format: format}
+}
+
+type aggExpressionOption struct {
+	expression string
+}
+
+func (e aggExpressionOption) applyAggregate(opts *AggregateReporter) {
+	opts.expression = &e.expression
+}
+
+// WithAggregateExpression supplies the JSONPath or Go-template expression
+// evaluated when the reporter is configured WithAggregateFormat(FormatJSONPath)
+// or WithAggregateFormat(FormatGoTemplate), same semantics as WithExpression.
+func WithAggregateExpression(expression string) AggregateOption {
+	return aggExpressionOption{expression: expression}
+}
+
+type aggRedactorOption struct {
+	redactor Redactor
+}
+
+func (r aggRedactorOption) applyAggregate(opts *AggregateReporter) {
+	opts.redactor = r.redactor
+}
+
+// WithAggregateRedactor rewrites every returned object through redactor
+// before it is serialized, same semantics as WithRedactor.
+func WithAggregateRedactor(redactor Redactor) AggregateOption {
+	return aggRedactorOption{redactor: redactor}
+}
+
+var NoAggregateDynamicCliSetError = errors.New("no dynamicCli set, use reporter.WithAggregateDynamicClient during initialization")
+var NoGVRsSetError = errors.New("no GroupVersionResources set, use reporter.WithGVRs during initialization")
+var NoRootSetError = errors.New("no root object set, use reporter.WithRoot during initialization")
+var NoAggregateExpressionSetError = errors.New("no expression set, use reporter.WithAggregateExpression during initialization when using FormatJSONPath or FormatGoTemplate")
+
+// NewAggregateReporter creates and validates an AggregateReporter.
+func NewAggregateReporter(opts ...AggregateOption) (AggregateReporter, error) {
+	instance := &AggregateReporter{}
+
+	for _, opt := range opts {
+		opt.applyAggregate(instance)
+	}
+
+	if instance.dynamicCli == nil {
+		return AggregateReporter{}, NoAggregateDynamicCliSetError
+	} else if len(instance.gvrs) == 0 {
+		return AggregateReporter{}, NoGVRsSetError
+	} else if instance.root == nil {
+		return AggregateReporter{}, NoRootSetError
+	} else if (instance.format == FormatJSONPath || instance.format == FormatGoTemplate) && instance.expression == nil {
+		return AggregateReporter{}, NoAggregateExpressionSetError
+	}
+
+	return *instance, nil
+}
+
+// Report walks metadata.ownerReferences transitively from the configured
+// root object and returns the encoded root together with everything it
+// transitively owns, across every GVR passed to WithGVRs.
+func (r AggregateReporter) Report(ctx context.Context) ([]string, error) {
+	root, err := (*r.dynamicCli).Resource(r.root.gvr).Namespace(r.root.namespace).Get(r.root.name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := r.listCandidates(r.root.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	owned := r.walkOwned(root.GetUID(), candidates)
+
+	reporter := YamlReporter{format: r.format, expression: r.expression, redactor: r.redactor}
+	resources := make([]string, 0, len(owned)+1)
+
+	out, err := reporter.encode(reporter.applyRedactor(root).Object)
+	if err != nil {
+		return nil, err
+	}
+	resources = append(resources, out)
+
+	for _, obj := range owned {
+		out, err := reporter.encode(reporter.applyRedactor(&obj).Object)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, out)
+	}
+
+	return resources, nil
+}
+
+// listCandidates lists every object across r.gvrs in namespace; these are
+// the objects searched for owner-reference matches while walking down from
+// the root.
+func (r AggregateReporter) listCandidates(namespace string) ([]unstructured.Unstructured, error) {
+	var candidates []unstructured.Unstructured
+
+	for _, gvr := range r.gvrs {
+		list, err := (*r.dynamicCli).Resource(gvr).Namespace(namespace).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, list.Items...)
+	}
+
+	return candidates, nil
+}
+
+// walkOwned performs a breadth-first search over candidates, following
+// metadata.ownerReferences down from rootUID up to r.maxDepth hops (or
+// until a round finds nothing new when r.maxDepth is 0).
+func (r AggregateReporter) walkOwned(rootUID types.UID, candidates []unstructured.Unstructured) []unstructured.Unstructured {
+	owners := map[types.UID]bool{rootUID: true}
+	seen := map[types.UID]bool{}
+	var owned []unstructured.Unstructured
+
+	for depth := 0; r.maxDepth == 0 || depth < r.maxDepth; depth++ {
+		nextOwners := map[types.UID]bool{}
+		foundAny := false
+
+		for _, candidate := range candidates {
+			if seen[candidate.GetUID()] {
+				continue
+			}
+			for _, ownerRef := range candidate.GetOwnerReferences() {
+				if owners[ownerRef.UID] {
+					seen[candidate.GetUID()] = true
+					owned = append(owned, candidate)
+					nextOwners[candidate.GetUID()] = true
+					foundAny = true
+					break
+				}
+			}
+		}
+
+		if !foundAny {
+			break
+		}
+		owners = nextOwners
+	}
+
+	return owned
+}
@@ -0,0 +1,146 @@
+package reporter_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic/fake"
+
+	"github.com/aerfio/k8s-reporter"
+)
+
+func newOwnedUnstructured(apiVersion, kind, namespace, name, uid string, owners ...metav1.OwnerReference) *unstructured.Unstructured {
+	obj := newUnstructured(apiVersion, kind, namespace, name)
+	obj.SetUID(types.UID(uid))
+	if len(owners) > 0 {
+		obj.SetOwnerReferences(owners)
+	}
+	return obj
+}
+
+func TestNewAggregateReporter(t *testing.T) {
+	t.Run("should fail without dynamic client", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		opts := []reporter.AggregateOption{
+			reporter.WithGVRs(schema.GroupVersionResource{}),
+			reporter.WithRoot(schema.GroupVersionResource{}, "name", "ns"),
+		}
+		_, err := reporter.NewAggregateReporter(opts...)
+		g.Expect(err).To(gomega.MatchError(reporter.NoAggregateDynamicCliSetError))
+	})
+
+	t.Run("should fail without GVRs", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		opts := []reporter.AggregateOption{
+			reporter.WithAggregateDynamicClient(fake.NewSimpleDynamicClient(runtime.NewScheme())),
+			reporter.WithRoot(schema.GroupVersionResource{}, "name", "ns"),
+		}
+		_, err := reporter.NewAggregateReporter(opts...)
+		g.Expect(err).To(gomega.MatchError(reporter.NoGVRsSetError))
+	})
+
+	t.Run("should fail without root", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		opts := []reporter.AggregateOption{
+			reporter.WithAggregateDynamicClient(fake.NewSimpleDynamicClient(runtime.NewScheme())),
+			reporter.WithGVRs(schema.GroupVersionResource{}),
+		}
+		_, err := reporter.NewAggregateReporter(opts...)
+		g.Expect(err).To(gomega.MatchError(reporter.NoRootSetError))
+	})
+
+	t.Run("should fail for FormatJSONPath without expression", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		opts := []reporter.AggregateOption{
+			reporter.WithAggregateDynamicClient(fake.NewSimpleDynamicClient(runtime.NewScheme())),
+			reporter.WithGVRs(schema.GroupVersionResource{}),
+			reporter.WithRoot(schema.GroupVersionResource{}, "name", "ns"),
+			reporter.WithAggregateFormat(reporter.FormatJSONPath),
+		}
+		_, err := reporter.NewAggregateReporter(opts...)
+		g.Expect(err).To(gomega.MatchError(reporter.NoAggregateExpressionSetError))
+	})
+}
+
+func TestAggregateReporter_Report(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	deployGVR := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	rsGVR := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "replicasets"}
+	podGVR := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"}
+
+	deploy := newOwnedUnstructured("apps/v1", "Deployment", "ns-foo", "my-deploy", "deploy-uid")
+	rs := newOwnedUnstructured("apps/v1", "ReplicaSet", "ns-foo", "my-deploy-abc123", "rs-uid",
+		metav1.OwnerReference{UID: "deploy-uid", Kind: "Deployment", Name: "my-deploy"})
+	pod1 := newOwnedUnstructured("v1", "Pod", "ns-foo", "my-deploy-abc123-xyz", "pod1-uid",
+		metav1.OwnerReference{UID: "rs-uid", Kind: "ReplicaSet", Name: "my-deploy-abc123"})
+	pod2 := newOwnedUnstructured("v1", "Pod", "ns-foo", "unrelated-pod", "pod2-uid")
+
+	dynamicCli := fake.NewSimpleDynamicClient(runtime.NewScheme(), deploy, rs, pod1, pod2)
+
+	opts := []reporter.AggregateOption{
+		reporter.WithAggregateDynamicClient(dynamicCli),
+		reporter.WithGVRs(deployGVR, rsGVR, podGVR),
+		reporter.WithRoot(deployGVR, "my-deploy", "ns-foo"),
+	}
+	r, err := reporter.NewAggregateReporter(opts...)
+	g.Expect(err).To(gomega.Succeed())
+
+	resources, err := r.Report(context.Background())
+	g.Expect(err).To(gomega.Succeed())
+	g.Expect(resources).To(gomega.HaveLen(3))
+	g.Expect(resources).To(gomega.ContainElement(gomega.ContainSubstring("my-deploy-abc123-xyz")))
+	g.Expect(resources).NotTo(gomega.ContainElement(gomega.ContainSubstring("unrelated-pod")))
+}
+
+func TestAggregateReporter_Report_JSONPath(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	deployGVR := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	deploy := newOwnedUnstructured("apps/v1", "Deployment", "ns-foo", "my-deploy", "deploy-uid")
+	dynamicCli := fake.NewSimpleDynamicClient(runtime.NewScheme(), deploy)
+
+	opts := []reporter.AggregateOption{
+		reporter.WithAggregateDynamicClient(dynamicCli),
+		reporter.WithGVRs(deployGVR),
+		reporter.WithRoot(deployGVR, "my-deploy", "ns-foo"),
+		reporter.WithAggregateFormat(reporter.FormatJSONPath),
+		reporter.WithAggregateExpression("{.metadata.name}"),
+	}
+	r, err := reporter.NewAggregateReporter(opts...)
+	g.Expect(err).To(gomega.Succeed())
+
+	resources, err := r.Report(context.Background())
+	g.Expect(err).To(gomega.Succeed())
+	g.Expect(resources).To(gomega.ConsistOf("my-deploy"))
+}
+
+func TestAggregateReporter_Report_Redactor(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	secretGVR := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "secrets"}
+	secret := newOwnedUnstructured("v1", "Secret", "ns-foo", "my-secret", "secret-uid")
+	secret.Object["data"] = map[string]interface{}{"password": "c2VjcmV0"}
+
+	dynamicCli := fake.NewSimpleDynamicClient(runtime.NewScheme(), secret)
+
+	opts := []reporter.AggregateOption{
+		reporter.WithAggregateDynamicClient(dynamicCli),
+		reporter.WithGVRs(secretGVR),
+		reporter.WithRoot(secretGVR, "my-secret", "ns-foo"),
+		reporter.WithAggregateRedactor(reporter.DefaultRedactor),
+	}
+	r, err := reporter.NewAggregateReporter(opts...)
+	g.Expect(err).To(gomega.Succeed())
+
+	resources, err := r.Report(context.Background())
+	g.Expect(err).To(gomega.Succeed())
+	g.Expect(resources).To(gomega.HaveLen(1))
+	g.Expect(resources[0]).NotTo(gomega.ContainSubstring("password"))
+}
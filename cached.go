@@ -0,0 +1,228 @@
+package reporter
+
+import (
+	"context"
+	"errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+// CachedReporter is an informer-backed Reader: List and Get are served from
+// an in-process cache kept in sync by a watch, instead of hitting the API
+// server on every call. It's built for long-running diagnostic tools that
+// report repeatedly, rather than one-shot dumps.
+type CachedReporter struct {
+	informer   cache.SharedIndexInformer
+	lister     cache.GenericLister
+	format     Format
+	expression *string
+	redactor   Redactor
+}
+
+var _ Reader = &CachedReporter{}
+
+// CachedReporterOption configures NewCachedReporter.
+type CachedReporterOption interface {
+	applyCached(*cachedReporterConfig)
+}
+
+type cachedReporterConfig struct {
+	format        Format
+	labelSelector string
+	fieldSelector string
+	expression    *string
+	redactor      Redactor
+}
+
+type cachedFormatOption struct {
+	format Format
+}
+
+func (f cachedFormatOption) applyCached(cfg *cachedReporterConfig) {
+	cfg.format = f.format
+}
+
+// WithCachedFormat selects the output format, same semantics as WithFormat.
+func WithCachedFormat(format Format) CachedReporterOption {
+	return cachedFormatOption{format: format}
+}
+
+type cachedLabelSelectorOption struct {
+	selector string
+}
+
+func (l cachedLabelSelectorOption) applyCached(cfg *cachedReporterConfig) {
+	cfg.labelSelector = l.selector
+}
+
+// WithCachedLabelSelector restricts the informer to objects matching the
+// given label selector.
+func WithCachedLabelSelector(selector string) CachedReporterOption {
+	return cachedLabelSelectorOption{selector: selector}
+}
+
+type cachedFieldSelectorOption struct {
+	selector string
+}
+
+func (f cachedFieldSelectorOption) applyCached(cfg *cachedReporterConfig) {
+	cfg.fieldSelector = f.selector
+}
+
+// WithCachedFieldSelector restricts the informer to objects matching the
+// given field selector.
+func WithCachedFieldSelector(selector string) CachedReporterOption {
+	return cachedFieldSelectorOption{selector: selector}
+}
+
+type cachedExpressionOption struct {
+	expression string
+}
+
+func (e cachedExpressionOption) applyCached(cfg *cachedReporterConfig) {
+	cfg.expression = &e.expression
+}
+
+// WithCachedExpression supplies the JSONPath or Go-template expression
+// evaluated when the reporter is configured WithCachedFormat(FormatJSONPath)
+// or WithCachedFormat(FormatGoTemplate), same semantics as WithExpression.
+func WithCachedExpression(expression string) CachedReporterOption {
+	return cachedExpressionOption{expression: expression}
+}
+
+type cachedRedactorOption struct {
+	redactor Redactor
+}
+
+func (r cachedRedactorOption) applyCached(cfg *cachedReporterConfig) {
+	cfg.redactor = r.redactor
+}
+
+// WithCachedRedactor rewrites every object List and Get return through
+// redactor before it is serialized, same semantics as WithRedactor.
+func WithCachedRedactor(redactor Redactor) CachedReporterOption {
+	return cachedRedactorOption{redactor: redactor}
+}
+
+var NotSupportedByCachedReporterError = errors.New("watch is not supported by CachedReporter, the informer already streams updates into its cache")
+var NoCachedExpressionSetError = errors.New("no expression set, use reporter.WithCachedExpression during initialization when using FormatJSONPath or FormatGoTemplate")
+
+// NewCachedReporter builds a dynamic informer for gvr in namespace (use ""
+// for all namespaces), starts it, waits for the initial sync and returns a
+// Reader backed by its cache. The informer is stopped when ctx is done.
+func NewCachedReporter(ctx context.Context, dynamicCli dynamic.Interface, gvr schema.GroupVersionResource, namespace string, opts ...CachedReporterOption) (*CachedReporter, error) {
+	cfg := &cachedReporterConfig{format: FormatYAML}
+	for _, opt := range opts {
+		opt.applyCached(cfg)
+	}
+
+	if (cfg.format == FormatJSONPath || cfg.format == FormatGoTemplate) && cfg.expression == nil {
+		return nil, NoCachedExpressionSetError
+	}
+
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicCli, 0, namespace, func(options *metav1.ListOptions) {
+		options.LabelSelector = cfg.labelSelector
+		options.FieldSelector = cfg.fieldSelector
+	})
+
+	genericInformer := factory.ForResource(gvr)
+
+	stopCh := make(chan struct{})
+	go func() {
+		<-ctx.Done()
+		close(stopCh)
+	}()
+
+	factory.Start(stopCh)
+	if !cache.WaitForCacheSync(stopCh, genericInformer.Informer().HasSynced) {
+		return nil, errors.New("failed to sync CachedReporter informer cache")
+	}
+
+	return &CachedReporter{
+		informer:   genericInformer.Informer(),
+		lister:     genericInformer.Lister(),
+		format:     cfg.format,
+		expression: cfg.expression,
+		redactor:   cfg.redactor,
+	}, nil
+}
+
+// List returns the cached objects matching opts (only WithNamespace and
+// MatchingLabels are meaningful; the cache already has everything the
+// informer was started with, so FieldSelector and Limit are ignored).
+// ctx is accepted for Reader compatibility; the cache is already local.
+func (r *CachedReporter) List(ctx context.Context, opts ...ListOption) ([]string, error) {
+	o := &ListOptions{}
+	for _, opt := range opts {
+		opt.applyToList(o)
+	}
+
+	selector := o.LabelSelector
+	if selector == nil {
+		selector = labels.Everything()
+	}
+
+	var items []*unstructured.Unstructured
+	raw, err := r.listerFor(o.Namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range raw {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		items = append(items, u)
+	}
+
+	reporter := YamlReporter{format: r.format, expression: r.expression, redactor: r.redactor}
+	resources := make([]string, 0, len(items))
+	for _, item := range items {
+		out, err := reporter.encode(reporter.applyRedactor(item).Object)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, out)
+	}
+	return resources, nil
+}
+
+// Get returns the cached object name in the namespace given via WithNamespace.
+func (r *CachedReporter) Get(ctx context.Context, name string, opts ...GetOption) (string, error) {
+	o := &GetOptions{}
+	for _, opt := range opts {
+		opt.applyToGet(o)
+	}
+
+	obj, err := r.listerFor(o.Namespace).Get(name)
+	if err != nil {
+		return "", err
+	}
+
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return "", errors.New("unexpected cached object type")
+	}
+
+	reporter := YamlReporter{format: r.format, expression: r.expression, redactor: r.redactor}
+	return reporter.encode(reporter.applyRedactor(u).Object)
+}
+
+// Watch is not supported by CachedReporter: the informer already keeps the
+// cache fresh, which is the continuous-reporting use case Watch exists for.
+func (r *CachedReporter) Watch(ctx context.Context, opts ...ListOption) (<-chan Event, error) {
+	return nil, NotSupportedByCachedReporterError
+}
+
+func (r *CachedReporter) listerFor(namespace string) cache.GenericNamespaceLister {
+	if namespace == "" {
+		return r.lister
+	}
+	return r.lister.ByNamespace(namespace)
+}
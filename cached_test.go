@@ -0,0 +1,96 @@
+package reporter_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+
+	"github.com/aerfio/k8s-reporter"
+)
+
+func TestNewCachedReporter(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	gvr := schema.GroupVersionResource{Group: "group", Version: "version", Resource: "pods"}
+	objects := []runtime.Object{
+		newUnstructured("group/version", "Pod", "ns-foo", "name-foo"),
+		newUnstructured("group/version", "Pod", "ns-foo", "name-foo2"),
+	}
+	dynamicCli := fake.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r, err := reporter.NewCachedReporter(ctx, dynamicCli, gvr, "ns-foo")
+	g.Expect(err).To(gomega.Succeed())
+
+	list, err := r.List(ctx, reporter.WithNamespace("ns-foo"))
+	g.Expect(err).To(gomega.Succeed())
+	g.Expect(list).To(gomega.HaveLen(2))
+
+	resource, err := r.Get(ctx, "name-foo", reporter.WithNamespace("ns-foo"))
+	g.Expect(err).To(gomega.Succeed())
+	g.Expect(resource).To(gomega.ContainSubstring("name-foo"))
+
+	_, err = r.Watch(ctx, reporter.WithNamespace("ns-foo"))
+	g.Expect(err).To(gomega.MatchError(reporter.NotSupportedByCachedReporterError))
+}
+
+func TestNewCachedReporter_FormatValidation(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	gvr := schema.GroupVersionResource{Group: "group", Version: "version", Resource: "pods"}
+	dynamicCli := fake.NewSimpleDynamicClient(runtime.NewScheme())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err := reporter.NewCachedReporter(ctx, dynamicCli, gvr, "ns-foo", reporter.WithCachedFormat(reporter.FormatJSONPath))
+	g.Expect(err).To(gomega.MatchError(reporter.NoCachedExpressionSetError))
+}
+
+func TestCachedReporter_List_Redactor(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	gvr := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "secrets"}
+	secret := newUnstructured("v1", "Secret", "ns-foo", "name-foo")
+	secret.Object["data"] = map[string]interface{}{"password": "c2VjcmV0"}
+	dynamicCli := fake.NewSimpleDynamicClient(runtime.NewScheme(), secret)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r, err := reporter.NewCachedReporter(ctx, dynamicCli, gvr, "ns-foo", reporter.WithCachedRedactor(reporter.DefaultRedactor))
+	g.Expect(err).To(gomega.Succeed())
+
+	list, err := r.List(ctx, reporter.WithNamespace("ns-foo"))
+	g.Expect(err).To(gomega.Succeed())
+	g.Expect(list).To(gomega.HaveLen(1))
+	g.Expect(list[0]).NotTo(gomega.ContainSubstring("password"))
+}
+
+func TestCachedReporter_List_JSONPath(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	gvr := schema.GroupVersionResource{Group: "group", Version: "version", Resource: "pods"}
+	objects := []runtime.Object{
+		newUnstructured("group/version", "Pod", "ns-foo", "name-foo"),
+	}
+	dynamicCli := fake.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r, err := reporter.NewCachedReporter(ctx, dynamicCli, gvr, "ns-foo",
+		reporter.WithCachedFormat(reporter.FormatJSONPath),
+		reporter.WithCachedExpression("{.metadata.name}"))
+	g.Expect(err).To(gomega.Succeed())
+
+	list, err := r.List(ctx, reporter.WithNamespace("ns-foo"))
+	g.Expect(err).To(gomega.Succeed())
+	g.Expect(list).To(gomega.ConsistOf("name-foo"))
+}
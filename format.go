@@ -0,0 +1,101 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"text/template"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// Format selects the output encoding produced by List and Get, mirroring
+// the output forms exposed by `kubectl -o`.
+type Format string
+
+const (
+	// FormatYAML marshals resources with ghodss/yaml. This is the default
+	// when no format is supplied.
+	FormatYAML Format = "yaml"
+	// FormatJSON marshals resources as indented JSON.
+	FormatJSON Format = "json"
+	// FormatTable renders the server-side Table printer output fetched via
+	// WithTableDynamicClient.
+	FormatTable Format = "table"
+	// FormatJSONPath evaluates the expression set via WithExpression as a
+	// JSONPath template, as in `kubectl -o jsonpath=...`.
+	FormatJSONPath Format = "jsonpath"
+	// FormatGoTemplate evaluates the expression set via WithExpression as a
+	// text/template, as in `kubectl -o go-template=...`.
+	FormatGoTemplate Format = "go-template"
+)
+
+type formatOption struct {
+	format Format
+}
+
+func (f formatOption) apply(opts *YamlReporter) {
+	opts.format = f.format
+}
+
+// WithFormat selects the output format produced by List and Get. When not
+// supplied the reporter defaults to FormatYAML.
+func WithFormat(format Format) Option {
+	return formatOption{format: format}
+}
+
+type expressionOption struct {
+	expression string
+}
+
+func (e expressionOption) apply(opts *YamlReporter) {
+	opts.expression = &e.expression
+}
+
+// WithExpression supplies the JSONPath or Go-template expression evaluated
+// when the reporter is configured WithFormat(FormatJSONPath) or
+// WithFormat(FormatGoTemplate).
+func WithExpression(expression string) Option {
+	return expressionOption{expression: expression}
+}
+
+// encode renders a single unstructured object according to r.format.
+func (r YamlReporter) encode(obj map[string]interface{}) (string, error) {
+	switch r.format {
+	case FormatJSON:
+		out, err := json.MarshalIndent(obj, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(out), nil
+	case FormatJSONPath:
+		jp := jsonpath.New("reporter")
+		if err := jp.Parse(*r.expression); err != nil {
+			return "", err
+		}
+		var buf bytes.Buffer
+		if err := jp.Execute(&buf, obj); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	case FormatGoTemplate:
+		tmpl, err := template.New("reporter").Parse(*r.expression)
+		if err != nil {
+			return "", err
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, obj); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	case FormatYAML, "":
+		out, err := yaml.Marshal(obj)
+		if err != nil {
+			return "", err
+		}
+		return string(out), nil
+	default:
+		return "", fmt.Errorf("unknown format %q", r.format)
+	}
+}
@@ -0,0 +1,95 @@
+package reporter_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+
+	"github.com/aerfio/k8s-reporter"
+)
+
+func TestNew_FormatValidation(t *testing.T) {
+	baseOpts := []reporter.Option{
+		reporter.WithDynamicClient(fake.NewSimpleDynamicClient(runtime.NewScheme())),
+		reporter.WithGVRSchema(schema.GroupVersionResource{}),
+	}
+
+	t.Run("should fail for FormatJSONPath without expression", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		opts := append(baseOpts, reporter.WithFormat(reporter.FormatJSONPath))
+		_, err := reporter.New(opts...)
+		g.Expect(err).To(gomega.MatchError(reporter.NoExpressionSetError))
+	})
+
+	t.Run("should fail for FormatGoTemplate without expression", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		opts := append(baseOpts, reporter.WithFormat(reporter.FormatGoTemplate))
+		_, err := reporter.New(opts...)
+		g.Expect(err).To(gomega.MatchError(reporter.NoExpressionSetError))
+	})
+
+	t.Run("should fail for FormatTable without table dynamic client", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		opts := append(baseOpts, reporter.WithFormat(reporter.FormatTable))
+		_, err := reporter.New(opts...)
+		g.Expect(err).To(gomega.MatchError(reporter.NoTableDynamicClientSetError))
+	})
+
+	t.Run("should succeed for FormatJSON with no extra options", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		opts := append(baseOpts, reporter.WithFormat(reporter.FormatJSON))
+		_, err := reporter.New(opts...)
+		g.Expect(err).To(gomega.Succeed())
+	})
+}
+
+func TestYamlReporter_Get_Formats(t *testing.T) {
+	ctx := context.Background()
+	objects := []runtime.Object{newUnstructured("group/version", "Pod", "ns-foo", "name-foo")}
+	gvr := schema.GroupVersionResource{Group: "group", Version: "version", Resource: "pods"}
+
+	tests := []struct {
+		name   string
+		format reporter.Format
+		expr   string
+		want   string
+	}{
+		{
+			name:   "json format",
+			format: reporter.FormatJSON,
+			want:   `"name": "name-foo"`,
+		},
+		{
+			name:   "jsonpath format",
+			format: reporter.FormatJSONPath,
+			expr:   "{.metadata.name}",
+			want:   "name-foo",
+		},
+		{
+			name:   "go-template format",
+			format: reporter.FormatGoTemplate,
+			expr:   "{{ .metadata.name }}",
+			want:   "name-foo",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			opts := reporterOptionsWithFakeClientAndGVR(gvr, objects...)
+			opts = append(opts, reporter.WithFormat(tt.format))
+			if tt.expr != "" {
+				opts = append(opts, reporter.WithExpression(tt.expr))
+			}
+			r, err := reporter.New(opts...)
+			g.Expect(err).To(gomega.Succeed())
+
+			resource, err := r.Get(ctx, "name-foo", reporter.WithNamespace("ns-foo"))
+			g.Expect(err).To(gomega.Succeed())
+			g.Expect(resource).To(gomega.ContainSubstring(tt.want))
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package reporter
+
+import (
+	"errors"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+)
+
+type gvkOption struct {
+	gvk schema.GroupVersionKind
+}
+
+func (g gvkOption) apply(opts *YamlReporter) {
+	opts.gvk = &g.gvk
+}
+
+// WithGVK resolves the supplied GroupVersionKind to a GroupVersionResource
+// and its scope (namespaced or cluster-scoped) via a discovery-backed REST
+// mapper, instead of requiring the caller to hand-compute the plural
+// GroupVersionResource themselves. Must be combined with WithRESTConfig.
+func WithGVK(gvk schema.GroupVersionKind) Option {
+	return gvkOption{gvk: gvk}
+}
+
+type restConfigOption struct {
+	restConfig *rest.Config
+}
+
+func (c restConfigOption) apply(opts *YamlReporter) {
+	opts.restConfig = c.restConfig
+}
+
+// WithRESTConfig supplies the rest.Config used to build the discovery client
+// and REST mapper backing WithGVK. When the dynamic client isn't separately
+// supplied via WithDynamicClient, it is also used to build one.
+func WithRESTConfig(restConfig *rest.Config) Option {
+	return restConfigOption{restConfig: restConfig}
+}
+
+var NoRESTConfigSetError = errors.New("no rest.Config set, use reporter.WithRESTConfig during initialization when using WithGVK")
+var NoGVKSetError = errors.New("no GroupVersionKind set, use reporter.WithGVK during initialization when using WithRESTConfig")
+
+// resolveGVK turns r.gvk into r.gvrSchema and r.namespaced using a
+// discovery-backed REST mapper, and builds a dynamic client from r.restConfig
+// if one wasn't already supplied via WithDynamicClient.
+func (r *YamlReporter) resolveGVK() error {
+	if r.gvk == nil {
+		return NoGVKSetError
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(r.restConfig)
+	if err != nil {
+		return err
+	}
+
+	restMapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+
+	mapping, err := restMapper.RESTMapping(r.gvk.GroupKind(), r.gvk.Version)
+	if err != nil {
+		return err
+	}
+
+	gvr := mapping.Resource
+	r.gvrSchema = &gvr
+	namespaced := mapping.Scope.Name() == meta.RESTScopeNameNamespace
+	r.namespaced = &namespaced
+
+	if r.dynamicCli == nil {
+		dynamicCli, err := dynamic.NewForConfig(r.restConfig)
+		if err != nil {
+			return err
+		}
+		var iface dynamic.Interface = dynamicCli
+		r.dynamicCli = &iface
+	}
+
+	return nil
+}
+
+// effectiveNamespace returns namespace unchanged, unless WithGVK resolved the
+// resource as cluster-scoped, in which case it returns "" so a caller-supplied
+// namespace can't be wrongly applied to e.g. Node or ClusterRole.
+func (r YamlReporter) effectiveNamespace(namespace string) string {
+	if r.namespaced != nil && !*r.namespaced {
+		return ""
+	}
+	return namespace
+}
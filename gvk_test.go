@@ -0,0 +1,107 @@
+package reporter_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/rest"
+
+	"github.com/aerfio/k8s-reporter"
+)
+
+// newDiscoveryServer serves the minimal /api, /api/v1 and /apis discovery
+// endpoints a REST mapper needs to resolve core/v1 GVKs, with one
+// namespaced (Pod) and one cluster-scoped (Node) resource.
+func newDiscoveryServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(metav1.APIVersions{Versions: []string{"v1"}})
+	})
+	mux.HandleFunc("/api/v1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(metav1.APIResourceList{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "pods", Kind: "Pod", Namespaced: true},
+				{Name: "nodes", Kind: "Node", Namespaced: false},
+			},
+		})
+	})
+	mux.HandleFunc("/apis", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(metav1.APIGroupList{})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNew_GVKValidation(t *testing.T) {
+	t.Run("should fail for WithGVK without a rest.Config", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		opts := []reporter.Option{reporter.WithGVK(schema.GroupVersionKind{Version: "v1", Kind: "Pod"})}
+		_, err := reporter.New(opts...)
+		g.Expect(err).To(gomega.MatchError(reporter.NoRESTConfigSetError))
+	})
+
+	t.Run("should fail for WithRESTConfig without a GVK", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		opts := []reporter.Option{
+			reporter.WithRESTConfig(&rest.Config{}),
+			reporter.WithGVRSchema(schema.GroupVersionResource{}),
+			reporter.WithDynamicClient(fake.NewSimpleDynamicClient(runtime.NewScheme())),
+		}
+		_, err := reporter.New(opts...)
+		g.Expect(err).To(gomega.MatchError(reporter.NoGVKSetError))
+	})
+}
+
+func TestNew_GVKResolution(t *testing.T) {
+	server := newDiscoveryServer(t)
+	restConfig := &rest.Config{Host: server.URL}
+
+	t.Run("should resolve a namespaced GVK to its GVR", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		objects := []runtime.Object{newUnstructured("v1", "Pod", "ns-foo", "name-foo")}
+		opts := []reporter.Option{
+			reporter.WithRESTConfig(restConfig),
+			reporter.WithGVK(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}),
+			reporter.WithDynamicClient(fake.NewSimpleDynamicClient(runtime.NewScheme(), objects...)),
+		}
+		r, err := reporter.New(opts...)
+		g.Expect(err).To(gomega.Succeed())
+
+		resource, err := r.Get(context.Background(), "name-foo", reporter.WithNamespace("ns-foo"))
+		g.Expect(err).To(gomega.Succeed())
+		g.Expect(resource).To(gomega.ContainSubstring("name-foo"))
+	})
+
+	t.Run("should resolve a cluster-scoped GVK and ignore a caller-supplied namespace", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		objects := []runtime.Object{newUnstructured("v1", "Node", "", "node-foo")}
+		opts := []reporter.Option{
+			reporter.WithRESTConfig(restConfig),
+			reporter.WithGVK(schema.GroupVersionKind{Version: "v1", Kind: "Node"}),
+			reporter.WithDynamicClient(fake.NewSimpleDynamicClient(runtime.NewScheme(), objects...)),
+		}
+		r, err := reporter.New(opts...)
+		g.Expect(err).To(gomega.Succeed())
+
+		resource, err := r.Get(context.Background(), "node-foo", reporter.WithNamespace("some-namespace"))
+		g.Expect(err).To(gomega.Succeed())
+		g.Expect(resource).To(gomega.ContainSubstring("node-foo"))
+	})
+}
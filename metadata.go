@@ -0,0 +1,88 @@
+package reporter
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/metadata"
+)
+
+type metadataCliOption struct {
+	MetadataCli metadata.Interface
+}
+
+func (m metadataCliOption) apply(opts *YamlReporter) {
+	opts.metadataCli = &m.MetadataCli
+}
+
+// WithMetadataClient supplies the metadata.Interface used when the reporter
+// is configured WithMetadataOnly.
+func WithMetadataClient(metadataCli metadata.Interface) Option {
+	return metadataCliOption{MetadataCli: metadataCli}
+}
+
+type metadataOnlyOption struct{}
+
+func (metadataOnlyOption) apply(opts *YamlReporter) {
+	opts.metadataOnly = true
+}
+
+// WithMetadataOnly switches List and Get to fetch only PartialObjectMetadata
+// (name, labels, annotations, ownerReferences, ...) through the
+// k8s.io/client-go/metadata client instead of full objects through the
+// dynamic client. Borrowed from controller-runtime's OnlyMetadata builder
+// option, this cuts memory and bytes-over-the-wire considerably when a
+// report only needs metadata, e.g. listing thousands of pods.
+func WithMetadataOnly() Option {
+	return metadataOnlyOption{}
+}
+
+func (r YamlReporter) listMetadata(o *ListOptions) ([]string, error) {
+	resources := []string{}
+	listOpts := o.AsMetaListOptions()
+
+	for {
+		list, err := r.metadataResource.Namespace(r.effectiveNamespace(o.Namespace)).List(listOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range list.Items {
+			obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&list.Items[i])
+			if err != nil {
+				return nil, err
+			}
+
+			u := r.applyRedactor(&unstructured.Unstructured{Object: obj})
+			out, err := r.encode(u.Object)
+			if err != nil {
+				return nil, err
+			}
+
+			resources = append(resources, out)
+		}
+
+		cont := list.GetContinue()
+		if o.Limit <= 0 || cont == "" {
+			break
+		}
+		listOpts.Continue = cont
+	}
+
+	return resources, nil
+}
+
+func (r YamlReporter) getMetadata(name string, o *GetOptions) (string, error) {
+	partialObj, err := r.metadataResource.Namespace(r.effectiveNamespace(o.Namespace)).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(partialObj)
+	if err != nil {
+		return "", err
+	}
+
+	u := r.applyRedactor(&unstructured.Unstructured{Object: obj})
+	return r.encode(u.Object)
+}
@@ -0,0 +1,143 @@
+package reporter_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	ktesting "k8s.io/client-go/testing"
+
+	"github.com/aerfio/k8s-reporter"
+)
+
+func newMetadataScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	if err := metav1.AddMetaToScheme(scheme); err != nil {
+		panic(err)
+	}
+	return scheme
+}
+
+func TestNew_MetadataOnlyValidation(t *testing.T) {
+	t.Run("should fail for WithMetadataOnly without metadata client", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		opts := []reporter.Option{reporter.WithGVRSchema(schema.GroupVersionResource{}), reporter.WithMetadataOnly()}
+		_, err := reporter.New(opts...)
+		g.Expect(err).To(gomega.MatchError(reporter.NoMetadataClientSetError))
+	})
+
+	t.Run("should succeed for WithMetadataOnly with metadata client", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		opts := []reporter.Option{
+			reporter.WithGVRSchema(schema.GroupVersionResource{}),
+			reporter.WithMetadataOnly(),
+			reporter.WithMetadataClient(metadatafake.NewSimpleMetadataClient(newMetadataScheme())),
+		}
+		_, err := reporter.New(opts...)
+		g.Expect(err).To(gomega.Succeed())
+	})
+}
+
+func TestYamlReporter_Get_MetadataOnly(t *testing.T) {
+	g := gomega.NewWithT(t)
+	gvr := schema.GroupVersionResource{Group: "group", Version: "version", Resource: "pods"}
+	objects := []runtime.Object{
+		&metav1.PartialObjectMetadata{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "group/version", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{Name: "name-foo", Namespace: "ns-foo"},
+		},
+	}
+
+	opts := []reporter.Option{
+		reporter.WithGVRSchema(gvr),
+		reporter.WithMetadataOnly(),
+		reporter.WithMetadataClient(metadatafake.NewSimpleMetadataClient(newMetadataScheme(), objects...)),
+	}
+	r, err := reporter.New(opts...)
+	g.Expect(err).To(gomega.Succeed())
+
+	resource, err := r.Get(context.Background(), "name-foo", reporter.WithNamespace("ns-foo"))
+	g.Expect(err).To(gomega.Succeed())
+	g.Expect(resource).To(gomega.ContainSubstring("name-foo"))
+	g.Expect(resource).To(gomega.ContainSubstring("ns-foo"))
+}
+
+func TestYamlReporter_Get_MetadataOnly_Redactor(t *testing.T) {
+	g := gomega.NewWithT(t)
+	gvr := schema.GroupVersionResource{Group: "group", Version: "version", Resource: "pods"}
+	objects := []runtime.Object{
+		&metav1.PartialObjectMetadata{
+			TypeMeta: metav1.TypeMeta{APIVersion: "group/version", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:          "name-foo",
+				Namespace:     "ns-foo",
+				ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+			},
+		},
+	}
+
+	opts := []reporter.Option{
+		reporter.WithGVRSchema(gvr),
+		reporter.WithMetadataOnly(),
+		reporter.WithMetadataClient(metadatafake.NewSimpleMetadataClient(newMetadataScheme(), objects...)),
+		reporter.WithRedactor(reporter.DefaultRedactor),
+	}
+	r, err := reporter.New(opts...)
+	g.Expect(err).To(gomega.Succeed())
+
+	resource, err := r.Get(context.Background(), "name-foo", reporter.WithNamespace("ns-foo"))
+	g.Expect(err).To(gomega.Succeed())
+	g.Expect(resource).NotTo(gomega.ContainSubstring("managedFields"))
+}
+
+func TestYamlReporter_List_MetadataOnly_Pagination(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	gvr := schema.GroupVersionResource{Group: "group", Version: "version", Resource: "pods"}
+	metadataCli := metadatafake.NewSimpleMetadataClient(newMetadataScheme())
+
+	page1 := &metav1.List{
+		ListMeta: metav1.ListMeta{Continue: "next-token"},
+		Items: []runtime.RawExtension{
+			{Object: &metav1.PartialObjectMetadata{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "group/version", Kind: "Pod"},
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-a", Namespace: "ns-foo"},
+			}},
+		},
+	}
+	page2 := &metav1.List{
+		Items: []runtime.RawExtension{
+			{Object: &metav1.PartialObjectMetadata{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "group/version", Kind: "Pod"},
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-b", Namespace: "ns-foo"},
+			}},
+		},
+	}
+
+	served := false
+	metadataCli.PrependReactor("list", "pods", func(action ktesting.Action) (bool, runtime.Object, error) {
+		if !served {
+			served = true
+			return true, page1, nil
+		}
+		return true, page2, nil
+	})
+
+	opts := []reporter.Option{
+		reporter.WithGVRSchema(gvr),
+		reporter.WithMetadataOnly(),
+		reporter.WithMetadataClient(metadataCli),
+	}
+	r, err := reporter.New(opts...)
+	g.Expect(err).To(gomega.Succeed())
+
+	list, err := r.List(context.Background(), reporter.WithNamespace("ns-foo"), reporter.Limit(1))
+	g.Expect(err).To(gomega.Succeed())
+	g.Expect(list).To(gomega.HaveLen(2))
+	g.Expect(list).To(gomega.ContainElement(gomega.ContainSubstring("pod-a")))
+	g.Expect(list).To(gomega.ContainElement(gomega.ContainSubstring("pod-b")))
+}
@@ -0,0 +1,91 @@
+package reporter
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ListOptions collects the configuration built up by a chain of ListOption
+// values, mirroring controller-runtime's client.ListOptions.
+type ListOptions struct {
+	Namespace     string
+	LabelSelector labels.Selector
+	FieldSelector fields.Selector
+	Limit         int64
+}
+
+// AsMetaListOptions converts to the metav1.ListOptions understood by the
+// dynamic client.
+func (o *ListOptions) AsMetaListOptions() metav1.ListOptions {
+	listOpts := metav1.ListOptions{}
+	if o.LabelSelector != nil {
+		listOpts.LabelSelector = o.LabelSelector.String()
+	}
+	if o.FieldSelector != nil {
+		listOpts.FieldSelector = o.FieldSelector.String()
+	}
+	if o.Limit > 0 {
+		listOpts.Limit = o.Limit
+	}
+	return listOpts
+}
+
+// ListOption applies a configuration option when calling List or Watch.
+type ListOption interface {
+	applyToList(*ListOptions)
+}
+
+// GetOptions collects the configuration built up by a chain of GetOption
+// values.
+type GetOptions struct {
+	Namespace string
+}
+
+// GetOption applies a configuration option when calling Get.
+type GetOption interface {
+	applyToGet(*GetOptions)
+}
+
+// NamespaceOption is a ListOption and a GetOption, returned by WithNamespace.
+type NamespaceOption interface {
+	ListOption
+	GetOption
+}
+
+type namespaceOption struct {
+	namespace string
+}
+
+func (n namespaceOption) applyToList(opts *ListOptions) { opts.Namespace = n.namespace }
+func (n namespaceOption) applyToGet(opts *GetOptions)   { opts.Namespace = n.namespace }
+
+// WithNamespace restricts List, Get or Watch to the given namespace.
+func WithNamespace(namespace string) NamespaceOption {
+	return namespaceOption{namespace: namespace}
+}
+
+// MatchingLabels filters List/Watch results to objects matching every
+// label, as in `r.List(ctx, MatchingLabels{"app": "x"})`.
+type MatchingLabels map[string]string
+
+func (m MatchingLabels) applyToList(opts *ListOptions) {
+	opts.LabelSelector = labels.SelectorFromSet(labels.Set(m))
+}
+
+// MatchingFields filters List/Watch results to objects matching every field
+// selector requirement, as in
+// `r.List(ctx, MatchingFields{"status.phase": "Running"})`.
+type MatchingFields map[string]string
+
+func (m MatchingFields) applyToList(opts *ListOptions) {
+	opts.FieldSelector = fields.SelectorFromSet(fields.Set(m))
+}
+
+// Limit caps the number of objects fetched per page. List transparently
+// pages through every page using the dynamic client's continue token, so
+// callers can safely list very large collections without holding them all
+// in the API server's memory at once.
+type Limit int64
+
+func (l Limit) applyToList(opts *ListOptions) { opts.Limit = int64(l) }
@@ -0,0 +1,56 @@
+package reporter_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/aerfio/k8s-reporter"
+)
+
+func newLabeledUnstructured(apiVersion, kind, namespace, name string, labels map[string]interface{}) *unstructured.Unstructured {
+	u := newUnstructured(apiVersion, kind, namespace, name)
+	u.Object["metadata"].(map[string]interface{})["labels"] = labels
+	return u
+}
+
+func TestYamlReporter_List_MatchingLabels(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	gvr := schema.GroupVersionResource{Group: "group", Version: "version", Resource: "pods"}
+	objects := []runtime.Object{
+		newLabeledUnstructured("group/version", "Pod", "ns-foo", "name-foo", map[string]interface{}{"app": "a"}),
+		newLabeledUnstructured("group/version", "Pod", "ns-foo", "name-bar", map[string]interface{}{"app": "b"}),
+	}
+
+	opts := reporterOptionsWithFakeClientAndGVR(gvr, objects...)
+	r, err := reporter.New(opts...)
+	g.Expect(err).To(gomega.Succeed())
+
+	list, err := r.List(context.Background(), reporter.WithNamespace("ns-foo"), reporter.MatchingLabels{"app": "a"})
+	g.Expect(err).To(gomega.Succeed())
+	g.Expect(list).To(gomega.HaveLen(1))
+	g.Expect(list[0]).To(gomega.ContainSubstring("name-foo"))
+}
+
+func TestYamlReporter_Get_Redactor(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	gvr := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "secrets"}
+	secret := newUnstructured("v1", "Secret", "ns-foo", "name-foo")
+	secret.Object["data"] = map[string]interface{}{"password": "c2VjcmV0"}
+	secret.Object["metadata"].(map[string]interface{})["managedFields"] = []interface{}{map[string]interface{}{"manager": "kubectl"}}
+
+	opts := append(reporterOptionsWithFakeClientAndGVR(gvr, secret), reporter.WithRedactor(reporter.DefaultRedactor))
+	r, err := reporter.New(opts...)
+	g.Expect(err).To(gomega.Succeed())
+
+	resource, err := r.Get(context.Background(), "name-foo", reporter.WithNamespace("ns-foo"))
+	g.Expect(err).To(gomega.Succeed())
+	g.Expect(resource).NotTo(gomega.ContainSubstring("password"))
+	g.Expect(resource).NotTo(gomega.ContainSubstring("managedFields"))
+}
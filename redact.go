@@ -0,0 +1,49 @@
+package reporter
+
+import "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+// Redactor rewrites an object before it is serialized, e.g. to strip
+// sensitive or noisy fields. It must not mutate u in place; return a copy.
+type Redactor func(u *unstructured.Unstructured) *unstructured.Unstructured
+
+type redactorOption struct {
+	redactor Redactor
+}
+
+func (r redactorOption) apply(opts *YamlReporter) {
+	opts.redactor = r.redactor
+}
+
+// WithRedactor rewrites every object List and Get return through redactor
+// before it is serialized. See DefaultRedactor for a built-in redactor that
+// strips Secret data and metadata.managedFields.
+//
+// This does not apply to FormatTable: the server-side Table printer already
+// reduces objects down to their printer columns before this package ever
+// sees them, so there is no raw object left to redact.
+func WithRedactor(redactor Redactor) Option {
+	return redactorOption{redactor: redactor}
+}
+
+// DefaultRedactor strips data and stringData from Secrets and
+// metadata.managedFields from every object, so reports can be shared
+// without leaking credentials or noisy field-manager bookkeeping.
+func DefaultRedactor(u *unstructured.Unstructured) *unstructured.Unstructured {
+	out := u.DeepCopy()
+
+	unstructured.RemoveNestedField(out.Object, "metadata", "managedFields")
+
+	if out.GroupVersionKind().Group == "" && out.GetKind() == "Secret" {
+		unstructured.RemoveNestedField(out.Object, "data")
+		unstructured.RemoveNestedField(out.Object, "stringData")
+	}
+
+	return out
+}
+
+func (r YamlReporter) applyRedactor(u *unstructured.Unstructured) *unstructured.Unstructured {
+	if r.redactor == nil {
+		return u
+	}
+	return r.redactor(u)
+}
@@ -4,21 +4,34 @@ import (
 	"context"
 	"errors"
 
-	"github.com/ghodss/yaml"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/rest"
 )
 
 type YamlReporter struct {
-	dynamicCli *dynamic.Interface
-	gvrSchema  *schema.GroupVersionResource
-	resource   dynamic.NamespaceableResourceInterface
+	dynamicCli       *dynamic.Interface
+	gvrSchema        *schema.GroupVersionResource
+	resource         dynamic.NamespaceableResourceInterface
+	format           Format
+	expression       *string
+	tableCli         *dynamic.Interface
+	tableResource    dynamic.NamespaceableResourceInterface
+	metadataCli      *metadata.Interface
+	metadataOnly     bool
+	metadataResource metadata.Getter
+	gvk              *schema.GroupVersionKind
+	restConfig       *rest.Config
+	namespaced       *bool
+	redactor         Redactor
 }
 
 type Reader interface {
-	List(ctx context.Context, namespace string, options metav1.ListOptions) ([]string, error)
-	Get(ctx context.Context, name, namespace string, options metav1.GetOptions) (string, error)
+	List(ctx context.Context, opts ...ListOption) ([]string, error)
+	Get(ctx context.Context, name string, opts ...GetOption) (string, error)
+	Watch(ctx context.Context, opts ...ListOption) (<-chan Event, error)
 }
 
 var _ Reader = &YamlReporter{}
@@ -39,6 +52,22 @@ func WithDynamicClient(dynamicCli dynamic.Interface) Option {
 	return dynCliOption{DynamicCli: dynamicCli}
 }
 
+type tableCliOption struct {
+	DynamicCli dynamic.Interface
+}
+
+func (d tableCliOption) apply(opts *YamlReporter) {
+	opts.tableCli = &d.DynamicCli
+}
+
+// WithTableDynamicClient supplies the dynamic client used for FormatTable.
+// It must be built from a rest.Config whose AcceptContentTypes includes
+// "application/json;as=Table;g=meta.k8s.io;v=v1" so that the API server
+// returns a metav1.Table instead of the resource itself.
+func WithTableDynamicClient(dynamicCli dynamic.Interface) Option {
+	return tableCliOption{DynamicCli: dynamicCli}
+}
+
 type gvrOption struct {
 	schema schema.GroupVersionResource
 }
@@ -59,59 +88,123 @@ func New(opts ...Option) (YamlReporter, error) {
 		opt.apply(instance)
 	}
 
+	if instance.gvk != nil {
+		if instance.restConfig == nil {
+			return YamlReporter{}, NoRESTConfigSetError
+		}
+		if err := instance.resolveGVK(); err != nil {
+			return YamlReporter{}, err
+		}
+	}
+
 	if err := instance.checkConfig(); err != nil {
 		return YamlReporter{}, err
 	}
 
-	instance.resource = (*instance.dynamicCli).Resource(*instance.gvrSchema)
+	if instance.metadataOnly {
+		instance.metadataResource = (*instance.metadataCli).Resource(*instance.gvrSchema)
+	} else {
+		instance.resource = (*instance.dynamicCli).Resource(*instance.gvrSchema)
+	}
+
+	if instance.tableCli != nil {
+		instance.tableResource = (*instance.tableCli).Resource(*instance.gvrSchema)
+	}
 
 	return *instance, nil
 }
 
 var NoDynamicCliSetError = errors.New("no dynamicCli set, use reporter.WithDynamicClient during initialization")
 var NoGroupVersionResourceSetError = errors.New("no GroupVersionResource set, use reporter.WithGVRSchema during initialization")
+var NoExpressionSetError = errors.New("no expression set, use reporter.WithExpression during initialization when using FormatJSONPath or FormatGoTemplate")
+var NoTableDynamicClientSetError = errors.New("no table dynamicCli set, use reporter.WithTableDynamicClient during initialization when using FormatTable")
+var NoMetadataClientSetError = errors.New("no metadataCli set, use reporter.WithMetadataClient during initialization when using WithMetadataOnly")
 
 func (r YamlReporter) checkConfig() error {
-	if r.dynamicCli == nil {
-		return NoDynamicCliSetError
-	} else if r.gvrSchema == nil {
+	if r.gvrSchema == nil {
 		return NoGroupVersionResourceSetError
+	} else if r.metadataOnly && r.metadataCli == nil {
+		return NoMetadataClientSetError
+	} else if !r.metadataOnly && r.dynamicCli == nil {
+		return NoDynamicCliSetError
+	} else if (r.format == FormatJSONPath || r.format == FormatGoTemplate) && r.expression == nil {
+		return NoExpressionSetError
+	} else if r.format == FormatTable && r.tableCli == nil {
+		return NoTableDynamicClientSetError
+	} else if r.restConfig != nil && r.gvk == nil {
+		return NoGVKSetError
 	}
 
 	return nil
 }
 
-func (r YamlReporter) List(ctx context.Context, namespace string, options metav1.ListOptions) ([]string, error) {
+func (r YamlReporter) List(ctx context.Context, opts ...ListOption) ([]string, error) {
 	// context is here for future, when we migrate to k8s libs for v1.18
-	unstructuredList, err := r.resource.Namespace(namespace).List(options)
-	if err != nil {
-		return nil, err
+	o := &ListOptions{}
+	for _, opt := range opts {
+		opt.applyToList(o)
+	}
+
+	if r.format == FormatTable {
+		table, err := r.listTable(o)
+		if err != nil {
+			return nil, err
+		}
+		return []string{table}, nil
+	}
+
+	if r.metadataOnly {
+		return r.listMetadata(o)
 	}
 
 	resources := []string{}
+	listOpts := o.AsMetaListOptions()
 
-	for _, item := range unstructuredList.Items {
-		out, err := yaml.Marshal(item.Object)
+	for {
+		unstructuredList, err := r.resource.Namespace(r.effectiveNamespace(o.Namespace)).List(listOpts)
 		if err != nil {
 			return nil, err
 		}
 
-		resources = append(resources, string(out))
+		for i := range unstructuredList.Items {
+			obj := r.applyRedactor(&unstructuredList.Items[i])
+			out, err := r.encode(obj.Object)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, out)
+		}
+
+		cont := unstructuredList.GetContinue()
+		if o.Limit <= 0 || cont == "" {
+			break
+		}
+		listOpts.Continue = cont
 	}
+
 	return resources, nil
 }
 
-func (r YamlReporter) Get(ctx context.Context, name, namespace string, options metav1.GetOptions) (string, error) {
+func (r YamlReporter) Get(ctx context.Context, name string, opts ...GetOption) (string, error) {
 	// context is here for future, when we migrate to k8s libs for v1.18
-	unstructuredObj, err := r.resource.Namespace(namespace).Get(name, options)
-	if err != nil {
-		return "", err
+	o := &GetOptions{}
+	for _, opt := range opts {
+		opt.applyToGet(o)
+	}
+
+	if r.format == FormatTable {
+		return r.getTable(name, o)
+	}
+
+	if r.metadataOnly {
+		return r.getMetadata(name, o)
 	}
 
-	out, err := yaml.Marshal(unstructuredObj.Object)
+	unstructuredObj, err := r.resource.Namespace(r.effectiveNamespace(o.Namespace)).Get(name, metav1.GetOptions{})
 	if err != nil {
 		return "", err
 	}
 
-	return string(out), nil
+	obj := r.applyRedactor(unstructuredObj)
+	return r.encode(obj.Object)
 }
@@ -6,7 +6,6 @@ import (
 
 	"github.com/onsi/gomega"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -50,7 +49,6 @@ func TestYamlReporter_Get(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	getOpts := metav1.GetOptions{}
 	tests := []struct {
 		name         string
 		args         args
@@ -101,7 +99,7 @@ func TestYamlReporter_Get(t *testing.T) {
 			opts := reporterOptionsWithFakeClientAndGVR(tt.args.schema, tt.args.objects...)
 			r, err := reporter.New(opts...)
 			g.Expect(err).To(gomega.Succeed())
-			resource, err := r.Get(ctx, tt.args.name, tt.args.namespace, getOpts)
+			resource, err := r.Get(ctx, tt.args.name, reporter.WithNamespace(tt.args.namespace))
 
 			if tt.wantErr {
 				g.Expect(err).NotTo(gomega.Succeed())
@@ -126,7 +124,6 @@ func TestYamlReporter_List(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	listOpts := metav1.ListOptions{}
 
 	tests := []struct {
 		name           string
@@ -178,7 +175,7 @@ func TestYamlReporter_List(t *testing.T) {
 			opts := reporterOptionsWithFakeClientAndGVR(tt.args.schema, tt.args.objects...)
 			r, err := reporter.New(opts...)
 			g.Expect(err).To(gomega.Succeed())
-			list, err := r.List(ctx, tt.args.namespace, listOpts)
+			list, err := r.List(ctx, reporter.WithNamespace(tt.args.namespace))
 			g.Expect(err).To(gomega.Succeed())
 			g.Expect(list).To(gomega.HaveLen(tt.expectedNumber))
 		})
@@ -0,0 +1,79 @@
+package reporter
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func (r YamlReporter) listTable(o *ListOptions) (string, error) {
+	table := &metav1.Table{}
+	listOpts := o.AsMetaListOptions()
+
+	for {
+		unstructuredList, err := r.tableResource.Namespace(r.effectiveNamespace(o.Namespace)).List(listOpts)
+		if err != nil {
+			return "", err
+		}
+
+		page := &metav1.Table{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredList.UnstructuredContent(), page); err != nil {
+			return "", err
+		}
+
+		if table.ColumnDefinitions == nil {
+			table.ColumnDefinitions = page.ColumnDefinitions
+		}
+		table.Rows = append(table.Rows, page.Rows...)
+
+		cont := page.GetContinue()
+		if o.Limit <= 0 || cont == "" {
+			break
+		}
+		listOpts.Continue = cont
+	}
+
+	return renderTable(table), nil
+}
+
+func (r YamlReporter) getTable(name string, o *GetOptions) (string, error) {
+	unstructuredObj, err := r.tableResource.Namespace(r.effectiveNamespace(o.Namespace)).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	table := &metav1.Table{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredObj.UnstructuredContent(), table); err != nil {
+		return "", err
+	}
+
+	return renderTable(table), nil
+}
+
+// renderTable formats a metav1.Table the way `kubectl get` prints it:
+// an upper-cased header row followed by one row per item, tab-aligned.
+func renderTable(table *metav1.Table) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', 0)
+
+	headers := make([]string, len(table.ColumnDefinitions))
+	for i, col := range table.ColumnDefinitions {
+		headers[i] = strings.ToUpper(col.Name)
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+
+	for _, row := range table.Rows {
+		cells := make([]string, len(row.Cells))
+		for i, cell := range row.Cells {
+			cells[i] = fmt.Sprintf("%v", cell)
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+
+	w.Flush()
+	return buf.String()
+}
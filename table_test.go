@@ -0,0 +1,162 @@
+package reporter_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/fake"
+
+	"github.com/aerfio/k8s-reporter"
+)
+
+// stubTableResource is a hand-rolled dynamic.NamespaceableResourceInterface
+// that serves canned Table-shaped List responses in sequence. It exists
+// because the fake dynamic client's List() reconstructs a fresh
+// UnstructuredList from tracker-managed objects and only copies their
+// ResourceVersion and Items across, dropping the top-level
+// columnDefinitions/rows/continue fields a real Table response carries -
+// there is no way to make it round-trip a Table through List().
+type stubTableResource struct {
+	dynamic.ResourceInterface
+	pages []*unstructured.UnstructuredList
+	calls int
+}
+
+func (s *stubTableResource) Namespace(string) dynamic.ResourceInterface { return s }
+
+func (s *stubTableResource) List(metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	page := s.pages[s.calls]
+	s.calls++
+	return page, nil
+}
+
+type stubTableDynamicClient struct {
+	resource *stubTableResource
+}
+
+func (s stubTableDynamicClient) Resource(schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return s.resource
+}
+
+var _ dynamic.Interface = stubTableDynamicClient{}
+var _ dynamic.NamespaceableResourceInterface = &stubTableResource{}
+
+// newTableUnstructured builds the metav1.Table-shaped unstructured object the
+// API server returns for FormatTable requests: a set of column definitions
+// plus one row per item. Its apiVersion/kind are set to the pod GVK rather
+// than meta.k8s.io/v1 Table so the fake object tracker files it under the
+// "pods" resource, exactly like the real server files a Table response under
+// the resource the request was made against.
+func newTableUnstructured(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	u := newUnstructured(apiVersion, kind, namespace, name)
+	u.Object["columnDefinitions"] = []interface{}{
+		map[string]interface{}{"name": "Name", "type": "string"},
+		map[string]interface{}{"name": "Age", "type": "string"},
+	}
+	u.Object["rows"] = []interface{}{
+		map[string]interface{}{"cells": []interface{}{name, "1d"}},
+	}
+	return u
+}
+
+func TestYamlReporter_Get_Table(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	gvr := schema.GroupVersionResource{Group: "group", Version: "version", Resource: "pods"}
+	tableObj := newTableUnstructured("group/version", "Pod", "ns-foo", "name-foo")
+
+	opts := []reporter.Option{
+		reporter.WithDynamicClient(fake.NewSimpleDynamicClient(runtime.NewScheme())),
+		reporter.WithGVRSchema(gvr),
+		reporter.WithFormat(reporter.FormatTable),
+		reporter.WithTableDynamicClient(fake.NewSimpleDynamicClient(runtime.NewScheme(), tableObj)),
+	}
+	r, err := reporter.New(opts...)
+	g.Expect(err).To(gomega.Succeed())
+
+	resource, err := r.Get(context.Background(), "name-foo", reporter.WithNamespace("ns-foo"))
+	g.Expect(err).To(gomega.Succeed())
+	g.Expect(resource).To(gomega.ContainSubstring("NAME"))
+	g.Expect(resource).To(gomega.ContainSubstring("AGE"))
+	g.Expect(resource).To(gomega.ContainSubstring("name-foo"))
+	g.Expect(resource).To(gomega.ContainSubstring("1d"))
+}
+
+// newTablePage builds a single Table-shaped List response page: column
+// definitions (only needed on the first page), one row, and an optional
+// continue token.
+func newTablePage(withColumns bool, rowName, continueToken string) *unstructured.UnstructuredList {
+	obj := map[string]interface{}{
+		"rows": []interface{}{
+			map[string]interface{}{"cells": []interface{}{rowName, "1d"}},
+		},
+	}
+	if withColumns {
+		obj["columnDefinitions"] = []interface{}{
+			map[string]interface{}{"name": "Name", "type": "string"},
+			map[string]interface{}{"name": "Age", "type": "string"},
+		}
+	}
+	if continueToken != "" {
+		obj["metadata"] = map[string]interface{}{"continue": continueToken}
+	}
+	return &unstructured.UnstructuredList{Object: obj}
+}
+
+func TestYamlReporter_List_Table(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	gvr := schema.GroupVersionResource{Group: "group", Version: "version", Resource: "pods"}
+	tableCli := stubTableDynamicClient{resource: &stubTableResource{
+		pages: []*unstructured.UnstructuredList{newTablePage(true, "name-foo", "")},
+	}}
+
+	opts := []reporter.Option{
+		reporter.WithDynamicClient(fake.NewSimpleDynamicClient(runtime.NewScheme())),
+		reporter.WithGVRSchema(gvr),
+		reporter.WithFormat(reporter.FormatTable),
+		reporter.WithTableDynamicClient(tableCli),
+	}
+	r, err := reporter.New(opts...)
+	g.Expect(err).To(gomega.Succeed())
+
+	list, err := r.List(context.Background(), reporter.WithNamespace("ns-foo"))
+	g.Expect(err).To(gomega.Succeed())
+	g.Expect(list).To(gomega.HaveLen(1))
+	g.Expect(list[0]).To(gomega.ContainSubstring("NAME"))
+	g.Expect(list[0]).To(gomega.ContainSubstring("name-foo"))
+}
+
+func TestYamlReporter_List_Table_Pagination(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	gvr := schema.GroupVersionResource{Group: "group", Version: "version", Resource: "pods"}
+	tableCli := stubTableDynamicClient{resource: &stubTableResource{
+		pages: []*unstructured.UnstructuredList{
+			newTablePage(true, "pod-a", "next-token"),
+			newTablePage(false, "pod-b", ""),
+		},
+	}}
+
+	opts := []reporter.Option{
+		reporter.WithDynamicClient(fake.NewSimpleDynamicClient(runtime.NewScheme())),
+		reporter.WithGVRSchema(gvr),
+		reporter.WithFormat(reporter.FormatTable),
+		reporter.WithTableDynamicClient(tableCli),
+	}
+	r, err := reporter.New(opts...)
+	g.Expect(err).To(gomega.Succeed())
+
+	list, err := r.List(context.Background(), reporter.WithNamespace("ns-foo"), reporter.Limit(1))
+	g.Expect(err).To(gomega.Succeed())
+	g.Expect(list).To(gomega.HaveLen(1))
+	g.Expect(list[0]).To(gomega.ContainSubstring("pod-a"))
+	g.Expect(list[0]).To(gomega.ContainSubstring("pod-b"))
+	g.Expect(tableCli.resource.calls).To(gomega.Equal(2))
+}
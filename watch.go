@@ -0,0 +1,79 @@
+package reporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Event represents a single watch notification. Verb describes what
+// happened (ADDED, MODIFIED, DELETED, ...) and Object is the affected
+// resource encoded per the reporter's configured Format.
+type Event struct {
+	Verb   string
+	Object string
+}
+
+var NotSupportedWithMetadataOnlyError = errors.New("watch is not supported together with WithMetadataOnly")
+
+// Watch streams List-style events for the namespace given via WithNamespace
+// until ctx is cancelled or
+// the underlying watch ends, whichever happens first. The returned channel
+// is closed in both cases.
+func (r YamlReporter) Watch(ctx context.Context, opts ...ListOption) (<-chan Event, error) {
+	if r.metadataOnly {
+		return nil, NotSupportedWithMetadataOnlyError
+	}
+
+	o := &ListOptions{}
+	for _, opt := range opts {
+		opt.applyToList(o)
+	}
+
+	w, err := r.resource.Namespace(r.effectiveNamespace(o.Namespace)).Watch(o.AsMetaListOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan Event)
+
+	go func() {
+		defer close(events)
+		defer w.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case watchEvent, ok := <-w.ResultChan():
+				if !ok {
+					return
+				}
+
+				out, err := r.encodeWatchObject(watchEvent.Object)
+				if err != nil {
+					continue
+				}
+
+				select {
+				case events <- Event{Verb: string(watchEvent.Type), Object: out}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+func (r YamlReporter) encodeWatchObject(obj runtime.Object) (string, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return "", fmt.Errorf("unexpected watch object type %T", obj)
+	}
+	return r.encode(r.applyRedactor(u).Object)
+}
@@ -0,0 +1,81 @@
+package reporter_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+
+	"github.com/aerfio/k8s-reporter"
+)
+
+func TestYamlReporter_Watch(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	gvr := schema.GroupVersionResource{Group: "group", Version: "version", Resource: "pods"}
+	dynamicCli := fake.NewSimpleDynamicClient(runtime.NewScheme())
+
+	opts := []reporter.Option{reporter.WithDynamicClient(dynamicCli), reporter.WithGVRSchema(gvr)}
+	r, err := reporter.New(opts...)
+	g.Expect(err).To(gomega.Succeed())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := r.Watch(ctx, reporter.WithNamespace("ns-foo"))
+	g.Expect(err).To(gomega.Succeed())
+
+	obj := newUnstructured("group/version", "Pod", "ns-foo", "name-foo")
+	_, err = dynamicCli.Resource(gvr).Namespace("ns-foo").Create(obj, metav1.CreateOptions{})
+	g.Expect(err).To(gomega.Succeed())
+
+	select {
+	case evt := <-events:
+		g.Expect(evt.Verb).To(gomega.Equal("ADDED"))
+		g.Expect(evt.Object).To(gomega.ContainSubstring("name-foo"))
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for watch event")
+	}
+
+	cancel()
+	_, ok := <-events
+	g.Expect(ok).To(gomega.BeFalse())
+}
+
+func TestYamlReporter_Watch_Redactor(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	gvr := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "secrets"}
+	dynamicCli := fake.NewSimpleDynamicClient(runtime.NewScheme())
+
+	opts := []reporter.Option{
+		reporter.WithDynamicClient(dynamicCli),
+		reporter.WithGVRSchema(gvr),
+		reporter.WithRedactor(reporter.DefaultRedactor),
+	}
+	r, err := reporter.New(opts...)
+	g.Expect(err).To(gomega.Succeed())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := r.Watch(ctx, reporter.WithNamespace("ns-foo"))
+	g.Expect(err).To(gomega.Succeed())
+
+	secret := newUnstructured("v1", "Secret", "ns-foo", "name-foo")
+	secret.Object["data"] = map[string]interface{}{"password": "c2VjcmV0"}
+	_, err = dynamicCli.Resource(gvr).Namespace("ns-foo").Create(secret, metav1.CreateOptions{})
+	g.Expect(err).To(gomega.Succeed())
+
+	select {
+	case evt := <-events:
+		g.Expect(evt.Object).NotTo(gomega.ContainSubstring("password"))
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for watch event")
+	}
+}